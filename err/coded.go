@@ -0,0 +1,144 @@
+package svcerr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Coded is implemented by errors created with NewCoded. It layers a
+//product-specific scope/category/detail code on top of the gRPC code,
+//for teams that need a stable business identifier alongside (not instead
+//of) the transport-level status.
+type Coded interface {
+	Scope() uint32
+	Category() uint32
+	Detail() uint32
+	//CodeStr - the scope/category/detail packed into a zero-padded
+	//6-digit string: scope*10000 + category*100 + detail.
+	CodeStr() string
+}
+
+type codedError struct {
+	*codeError
+	scope, category, detail uint32
+}
+
+func (e *codedError) Scope() uint32    { return e.scope }
+func (e *codedError) Category() uint32 { return e.category }
+func (e *codedError) Detail() uint32   { return e.detail }
+
+func (e *codedError) CodeStr() string {
+	return fmt.Sprintf("%06d", e.scope*10000+e.category*100+e.detail)
+}
+
+//NewCoded - creates an error carrying a business code (scope, category,
+//detail), attached to the status as an errdetails.ErrorInfo so it
+//survives a gRPC hop. The underlying gRPC code is Unknown since the
+//business code, not the transport code, is what callers are meant to
+//classify on; use ScopeOf/CategoryOf/DetailOf/FullCode to read it back.
+func NewCoded(scope, category, detail uint32, msg string, details ...proto.Message) error {
+	e := &codedError{scope: scope, category: category, detail: detail}
+	info := &errdetails.ErrorInfo{
+		Reason: e.CodeStr(),
+		Domain: fmt.Sprintf("scope-%d", scope),
+	}
+	e.codeError = newErr(codes.Unknown, msg, append([]proto.Message{info}, details...)...).(*codeError)
+	return e
+}
+
+//ScopeOf - returns the Scope of err's business code, or 0 if err wasn't
+//created with NewCoded (and doesn't wrap one).
+func ScopeOf(err error) uint32 {
+	if c, ok := asCoded(err); ok {
+		return c.Scope()
+	}
+	return 0
+}
+
+//CategoryOf - returns the Category of err's business code, or 0 if err
+//wasn't created with NewCoded (and doesn't wrap one).
+func CategoryOf(err error) uint32 {
+	if c, ok := asCoded(err); ok {
+		return c.Category()
+	}
+	return 0
+}
+
+//DetailOf - returns the Detail of err's business code, or 0 if err wasn't
+//created with NewCoded (and doesn't wrap one).
+func DetailOf(err error) uint32 {
+	if c, ok := asCoded(err); ok {
+		return c.Detail()
+	}
+	return 0
+}
+
+//FullCode - returns the padded 6-digit CodeStr of err's business code, or
+//"" if err wasn't created with NewCoded (and doesn't wrap one).
+func FullCode(err error) string {
+	if c, ok := asCoded(err); ok {
+		return c.CodeStr()
+	}
+	return ""
+}
+
+//parsedCoded rebuilds a Coded from an errdetails.ErrorInfo's Reason
+//(the zero-padded 6-digit CodeStr) when no codedError survived the
+//error chain -- the case after a real gRPC hop, where the receiver only
+//ever sees a generic status error (or, via FromGRPC, a plain codeError).
+type parsedCoded struct {
+	scope, category, detail uint32
+}
+
+func (c parsedCoded) Scope() uint32    { return c.scope }
+func (c parsedCoded) Category() uint32 { return c.category }
+func (c parsedCoded) Detail() uint32   { return c.detail }
+
+func (c parsedCoded) CodeStr() string {
+	return fmt.Sprintf("%06d", c.scope*10000+c.category*100+c.detail)
+}
+
+func asCoded(err error) (Coded, bool) {
+	var c Coded
+	if errors.As(err, &c) {
+		return c, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		if parsed, ok := parseCodeStr(info.Reason); ok {
+			return parsed, true
+		}
+	}
+	return nil, false
+}
+
+//parseCodeStr reverses codedError.CodeStr: a valid code is exactly 6
+//digits, packed as scope*10000 + category*100 + detail.
+func parseCodeStr(s string) (parsedCoded, bool) {
+	if len(s) != 6 {
+		return parsedCoded{}, false
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return parsedCoded{}, false
+	}
+	return parsedCoded{
+		scope:    uint32(n / 10000),
+		category: uint32(n / 100 % 100),
+		detail:   uint32(n % 100),
+	}, true
+}
@@ -0,0 +1,78 @@
+package svcerr
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/status"
+)
+
+func TestNewCoded(t *testing.T) {
+	err := NewCoded(1, 2, 3, "business rule violated")
+
+	if got, want := ScopeOf(err), uint32(1); got != want {
+		t.Errorf("ScopeOf() = %d, want %d", got, want)
+	}
+	if got, want := CategoryOf(err), uint32(2); got != want {
+		t.Errorf("CategoryOf() = %d, want %d", got, want)
+	}
+	if got, want := DetailOf(err), uint32(3); got != want {
+		t.Errorf("DetailOf() = %d, want %d", got, want)
+	}
+	if got, want := FullCode(err), "010203"; got != want {
+		t.Errorf("FullCode() = %q, want %q", got, want)
+	}
+}
+
+func TestScopeOfNonCoded(t *testing.T) {
+	err := NotFound("missing record")
+
+	if got := ScopeOf(err); got != 0 {
+		t.Errorf("ScopeOf() = %d, want 0", got)
+	}
+	if got := FullCode(err); got != "" {
+		t.Errorf("FullCode() = %q, want \"\"", got)
+	}
+}
+
+func TestNewCodedThroughWrap(t *testing.T) {
+	err := Wrap("handling request", NewCoded(4, 5, 6, "business rule violated"))
+
+	if got, want := FullCode(err), "040506"; got != want {
+		t.Errorf("FullCode() = %q, want %q", got, want)
+	}
+}
+
+func TestConvHTTPBusinessCode(t *testing.T) {
+	err := NewCoded(1, 2, 3, "business rule violated")
+
+	se, _ := ConvHTTP(err)
+	if got, want := se.Rest.BusinessCode, "010203"; got != want {
+		t.Errorf("ConvHTTP().Rest.BusinessCode = %q, want %q", got, want)
+	}
+}
+
+//TestFullCodeSurvivesGRPCHop exercises the scenario NewCoded exists
+//for: a business code set by one service read back by another after a
+//real gRPC hop, where the receiver never sees a *codedError, only a
+//generic status error (or, via FromGRPC, a plain *codeError). asCoded
+//must fall back to the errdetails.ErrorInfo detail in that case.
+func TestFullCodeSurvivesGRPCHop(t *testing.T) {
+	err := NewCoded(1, 2, 3, "business rule violated")
+
+	rebuilt := FromGRPC(ToGRPC(err))
+	if got, want := FullCode(rebuilt), "010203"; got != want {
+		t.Errorf("FullCode(FromGRPC(ToGRPC(err))) = %q, want %q", got, want)
+	}
+	se, _ := ConvHTTP(rebuilt)
+	if got, want := se.Rest.BusinessCode, "010203"; got != want {
+		t.Errorf("ConvHTTP(FromGRPC(ToGRPC(err))).Rest.BusinessCode = %q, want %q", got, want)
+	}
+
+	// Simulate the wire itself, bypassing ToGRPC/FromGRPC entirely: a
+	// plain status error rebuilt from a serialized proto, the way grpc-go
+	// hands it to a client after a real RPC.
+	wire := status.FromProto(status.Convert(err).Proto())
+	if got, want := FullCode(wire.Err()), "010203"; got != want {
+		t.Errorf("FullCode(status.FromProto(...)) = %q, want %q", got, want)
+	}
+}
@@ -5,6 +5,7 @@ package svcerr
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -76,11 +77,20 @@ type RestErr struct {
 	Desc    string `json:"Desc"`
 	Message string `json:"Message"`
 	Details string `json:"Details"`
+	//BusinessCode - the scope/category/detail code set via NewCoded, if
+	//any; a stable identifier REST clients can key off independently of
+	//the HTTP status code.
+	BusinessCode string `json:"BusinessCode,omitempty"`
+	//QuotaViolations - the violations of any QuotaFailure detail attached
+	//to the error, listed here as well as in Details so clients can act on
+	//them (e.g. display which quota was exceeded) without parsing Details
+	//text.
+	QuotaViolations []*QuotaViolation `json:"QuotaViolations,omitempty"`
 }
 
 //Wrap - Function to wrap an error within a new error
 func Wrap(text string, err error) error {
-	return fmt.Errorf("%s:(%w)", text, err)
+	return fmt.Errorf("%s:(%w)", text, classify(err))
 }
 
 //Below are the Helper functions to create errors
@@ -170,36 +180,50 @@ func Unauthenticated(msg string, details ...proto.Message) error {
 
 //internal function creates new error and adds message details
 func newErr(c codes.Code, message string, details ...proto.Message) error {
-	err := status.Errorf(c, message)
+	err := status.Error(c, message)
+	if stackEnabled() {
+		details = append(details, &DebugInfo{Detail: stackMarker, StackEntries: captureFrames(2)})
+	}
+	details = append(details, localizedDetails(MessageKey(c.String()))...)
 	if len(details) != 0 {
 		s := status.Convert(err)
 		if errDetail, lerr := s.WithDetails(details...); lerr != nil {
-			return err
+			return &codeError{err: err, code: c}
 		} else {
-			return errDetail.Err()
+			err = errDetail.Err()
 		}
 	}
-	return err
+	return &codeError{err: err, code: c}
 }
 
-//ConvHTTP - converts grpc error into Http Error structure
-func ConvHTTP(err error) (se SvcErr) {
+//ConvHTTP - converts grpc error into Http Error structure, along with the
+//http.Header implied by any RetryInfo/ResourceInfo details attached to
+//the error (see headersFor).
+func ConvHTTP(err error) (se SvcErr, header http.Header) {
 	st, ok := status.FromError(err)
 	if ok {
 		se.Rest = RestErr{
-			Code:    httpMap[st.Code()],
-			Desc:    st.Code().String(),
-			Message: st.Message(),
-			Details: fmt.Sprintf("%s", st.Details()),
+			Code:         httpMap[st.Code()],
+			Desc:         st.Code().String(),
+			Message:      st.Message(),
+			Details:      detailsString(visibleDetails(st.Details())),
+			BusinessCode: FullCode(err),
+		}
+		for _, d := range st.Details() {
+			if qf, ok := d.(*QuotaFailure); ok {
+				se.Rest.QuotaViolations = append(se.Rest.QuotaViolations, qf.Violations...)
+			}
 		}
+		header = headersFor(st.Details())
 	} else {
 		se.Rest = RestErr{
 			Code: http.StatusInternalServerError,
 			Desc: err.Error(),
 		}
+		header = http.Header{}
 	}
 	se.LocalTime = time.Now()
-	return se
+	return se, header
 }
 
 //IsValid - returns true if error is of rpc status type
@@ -215,12 +239,21 @@ func String(err error) string {
 		return fmt.Sprintf("Code = %s, Message = %s, Details = %s",
 			s.Code().String(),
 			s.Message(),
-			s.Details(),
+			detailsString(visibleDetails(s.Details())),
 		)
 	}
 	return s.Message()
 }
 
+//detailsString renders status details with single-space field separators.
+//protobuf's generated String() can pad a message's fields with extra
+//whitespace depending on what else in the binary has already triggered
+//lazy descriptor initialisation for that type; normalising here keeps
+//ConvHTTP/String output stable regardless of that.
+func detailsString(details []interface{}) string {
+	return strings.Join(strings.Fields(fmt.Sprintf("%s", details)), " ")
+}
+
 //Code - returns internal code from error
 func Code(err error) codes.Code {
 	s, ok := status.FromError(err)
@@ -219,7 +219,7 @@ func ExampleInvalidArgs() {
 
 	err := InvalidArgs("Additional Message") //Create Invalid Args error with additional description
 	fmt.Println(err)                         //Print err info - code and message
-	h := ConvHTTP(err)                       //Convert to HTTP err format, can be used with json encoding
+	h, _ := ConvHTTP(err)                       //Convert to HTTP err format, can be used with json encoding
 	fmt.Printf("%+v\n", h.Rest)              //Print err in HTTP-Json Format
 
 	fmt.Println("Creating Error with Details")
@@ -234,15 +234,15 @@ func ExampleInvalidArgs() {
 	derr := InvalidArgs("Additional Message", &br) //Create Invalid Args error by passing addition description and violation details
 	fmt.Println(derr)                              //Print err info - code and message
 	fmt.Println(String(derr))                      //Print err with Details using String func
-	h = ConvHTTP(derr)                             //Convert to HTTP err format, can be used with json encoding
+	h, _ = ConvHTTP(derr)                             //Convert to HTTP err format, can be used with json encoding
 	fmt.Printf("%+v", h.Rest)                      //Print err in HTTP-Json Format
 	// Output:
 	// rpc error: code = InvalidArgument desc = Additional Message
-	// {Code:400 Desc:InvalidArgument Message:Additional Message Details:[]}
+	// {Code:400 Desc:InvalidArgument Message:Additional Message Details:[] BusinessCode: QuotaViolations:[]}
 	// Creating Error with Details
 	// rpc error: code = InvalidArgument desc = Additional Message
-	// Code = InvalidArgument, Message = Additional Message, Details = [field_violations:<field:"Field Name" description:"mandatory field not provided" > ]
-	// {Code:400 Desc:InvalidArgument Message:Additional Message Details:[field_violations:<field:"Field Name" description:"mandatory field not provided" > ]}
+	// Code = InvalidArgument, Message = Additional Message, Details = [field_violations:{field:"Field Name" description:"mandatory field not provided"}]
+	// {Code:400 Desc:InvalidArgument Message:Additional Message Details:[field_violations:{field:"Field Name" description:"mandatory field not provided"}] BusinessCode: QuotaViolations:[]}
 }
 
 func TestConvHTTP(t *testing.T) {
@@ -291,7 +291,7 @@ func TestConvHTTP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if gotSe := ConvHTTP(tt.args.err); !reflect.DeepEqual(gotSe.Rest, tt.wantSe.Rest) {
+			if gotSe, _ := ConvHTTP(tt.args.err); !reflect.DeepEqual(gotSe.Rest, tt.wantSe.Rest) {
 				t.Errorf("ConvHTTP() = %v, want %v", gotSe, tt.wantSe)
 			}
 		})
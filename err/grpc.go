@@ -0,0 +1,143 @@
+package svcerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//chainPrefix marks a DebugInfo detail added by ToGRPC to carry one Wrap
+//layer of an error chain, so FromGRPC can tell it apart from details the
+//caller attached on purpose.
+const chainPrefix = "svcerr.chain: "
+
+var (
+	detailTypesMu sync.RWMutex
+	detailTypes   = map[string]func() proto.Message{}
+)
+
+//RegisterDetailType - registers a proto message type by its protobuf full
+//name so FromGRPC can decode it back into the original Go type even when
+//the type isn't otherwise registered with the global proto registry on
+//the receiving side (e.g. a dynamically-described message). Call this
+//once at init time for any custom detail type passed to the
+//constructors' details argument.
+func RegisterDetailType(msg proto.Message) {
+	name := proto.MessageName(msg)
+	msgType := proto.MessageV2(msg).ProtoReflect().Type()
+	detailTypesMu.Lock()
+	detailTypes[name] = func() proto.Message {
+		return proto.MessageV1(msgType.New().Interface())
+	}
+	detailTypesMu.Unlock()
+}
+
+func lookupDetailType(name string) (proto.Message, bool) {
+	detailTypesMu.RLock()
+	newMsg, ok := detailTypes[name]
+	detailTypesMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return newMsg(), true
+}
+
+//grpcStatuser is implemented by codeError and by grpc's own status errors;
+//layers stops unwrapping as soon as it reaches one, treating it as the base
+//of the chain rather than descending into its internal cause.
+type grpcStatuser interface {
+	GRPCStatus() *status.Status
+}
+
+//layers walks an error chain built with Wrap and returns, outermost
+//first, the text each layer added on top of the next one, along with the
+//base: the first (outermost) error in the chain carrying a gRPC status,
+//or the innermost error if none does.
+func layers(err error) (layers []string, base error) {
+	cur := err
+	for {
+		if _, ok := cur.(grpcStatuser); ok {
+			return layers, cur
+		}
+		next := errors.Unwrap(cur)
+		if next == nil {
+			return layers, cur
+		}
+		suffix := fmt.Sprintf(":(%s)", next.Error())
+		local := strings.TrimSuffix(cur.Error(), suffix)
+		layers = append(layers, local)
+		cur = next
+	}
+}
+
+//ToGRPC - encodes err, including chains built with Wrap, into a single
+//gRPC status error. The outermost gRPC code found while walking the
+//chain is used for the resulting status (codes.Unknown if err, or
+//whatever it wraps, never carried one); every Wrap layer above that code
+//is preserved as an errdetails.DebugInfo detail so FromGRPC can rebuild
+//an equivalent chain.
+func ToGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	wraps, base := layers(err)
+
+	st, ok := status.FromError(base)
+	if !ok {
+		st = status.New(codes.Unknown, base.Error())
+	}
+	for _, l := range wraps {
+		if withDetails, derr := st.WithDetails(&DebugInfo{Detail: chainPrefix + l}); derr == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+//FromGRPC - reverses ToGRPC: it rebuilds an error chain from a gRPC status
+//error such that errors.Is/errors.As see the same sentinels and detail
+//types the sender had, and Wrap layers read back in their original order.
+//err is returned unchanged if it doesn't carry a gRPC status.
+func FromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var (
+		wraps   []string
+		details []proto.Message
+	)
+	for i, d := range st.Details() {
+		if di, ok := d.(*DebugInfo); ok && strings.HasPrefix(di.Detail, chainPrefix) {
+			wraps = append(wraps, strings.TrimPrefix(di.Detail, chainPrefix))
+			continue
+		}
+		if m, ok := d.(proto.Message); ok {
+			details = append(details, m)
+			continue
+		}
+		// d couldn't be decoded via the global proto registry; fall back
+		// to a type registered with RegisterDetailType.
+		if raw := st.Proto().GetDetails()[i]; raw != nil {
+			name := raw.TypeUrl[strings.LastIndex(raw.TypeUrl, "/")+1:]
+			if msg, ok := lookupDetailType(name); ok && proto.Unmarshal(raw.Value, msg) == nil {
+				details = append(details, msg)
+			}
+		}
+	}
+
+	result := newErr(st.Code(), st.Message(), details...)
+	for i := len(wraps) - 1; i >= 0; i-- {
+		result = Wrap(wraps[i], result)
+	}
+	return result
+}
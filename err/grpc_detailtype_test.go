@@ -0,0 +1,111 @@
+package svcerr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+//newCustomDetailDescriptor builds a one-field message descriptor entirely
+//at runtime, with no corresponding Go package registering it with the
+//global proto registry -- the same situation a message type generated by
+//a service the receiver doesn't import would be in.
+func newCustomDetailDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("svcerr_test/custom_detail.proto"),
+		Package: proto.String("svcerr.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("CustomDetail"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("note"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("note"),
+					},
+				},
+			},
+		},
+	}
+	file, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() = %v", err)
+	}
+	return file.Messages().Get(0)
+}
+
+//TestRegisterDetailTypeFallback exercises RegisterDetailType/FromGRPC's
+//custom-type fallback path for a message type that isn't in the global
+//proto registry, so status.Details() can't decode it on its own (proven
+//below) and FromGRPC must fall back to the detailTypes registry and the
+//raw Any bytes. The type not being globally registered also means the
+//rebuilt status can't be auto-decoded either, so the round trip is
+//verified at the wire level: the TypeUrl and marshaled bytes FromGRPC's
+//reconstructed message produces must match the original exactly.
+func TestRegisterDetailTypeFallback(t *testing.T) {
+	desc := newCustomDetailDescriptor(t)
+	noteField := desc.Fields().ByName("note")
+
+	sample := dynamicpb.NewMessage(desc)
+	RegisterDetailType(sample)
+	t.Cleanup(func() {
+		detailTypesMu.Lock()
+		delete(detailTypes, proto.MessageName(sample))
+		detailTypesMu.Unlock()
+	})
+
+	detail := dynamicpb.NewMessage(desc)
+	detail.Set(noteField, protoreflect.ValueOfString("disk offline"))
+	wantBytes, merr := proto.Marshal(detail)
+	if merr != nil {
+		t.Fatalf("proto.Marshal(detail) = %v", merr)
+	}
+
+	err := newErr(codes.Internal, "custom detail attached", detail)
+
+	// Confirm the premise: without the detailTypes registry, the global
+	// registry alone can't resolve this type.
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("status.FromError(err) ok = false")
+	}
+	for _, d := range st.Details() {
+		if _, isErr := d.(error); !isErr {
+			t.Fatalf("detail decoded via the global registry = %v, want an unmarshal error (test premise broken)", d)
+		}
+	}
+
+	converted := ToGRPC(err)
+	rebuilt := FromGRPC(converted)
+
+	rst, ok := status.FromError(rebuilt)
+	if !ok {
+		t.Fatalf("FromGRPC(ToGRPC(err)) = %v, want a status error", rebuilt)
+	}
+
+	raws := rst.Proto().GetDetails()
+	if len(raws) != 1 {
+		t.Fatalf("rebuilt detail count = %d, want 1 (detailTypes fallback likely dropped it)", len(raws))
+	}
+
+	wantName := string(desc.FullName())
+	gotName := raws[0].TypeUrl[strings.LastIndex(raws[0].TypeUrl, "/")+1:]
+	if gotName != wantName {
+		t.Errorf("rebuilt detail type = %q, want %q", gotName, wantName)
+	}
+	if !bytes.Equal(raws[0].Value, wantBytes) {
+		t.Errorf("rebuilt detail bytes = %x, want %x", raws[0].Value, wantBytes)
+	}
+}
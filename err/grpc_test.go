@@ -0,0 +1,66 @@
+package svcerr
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGRPCFromGRPCRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "Plain Status Error",
+			err:  NotFound("missing record"),
+		},
+		{
+			name: "Single Wrap Layer",
+			err:  Wrap("loading user", NotFound("missing record")),
+		},
+		{
+			name: "Nested Wrap Layers",
+			err:  Wrap("handling request", Wrap("loading user", NotFound("missing record"))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted := ToGRPC(tt.err)
+			if _, ok := status.FromError(converted); !ok {
+				t.Fatalf("ToGRPC(%v) did not produce a gRPC status error", tt.err)
+			}
+
+			rebuilt := FromGRPC(converted)
+			if rebuilt.Error() != tt.err.Error() {
+				t.Errorf("FromGRPC(ToGRPC(err)) = %q, want %q", rebuilt.Error(), tt.err.Error())
+			}
+			if status.Code(rebuilt) != status.Code(tt.err) {
+				t.Errorf("FromGRPC(ToGRPC(err)) code = %v, want %v", status.Code(rebuilt), status.Code(tt.err))
+			}
+		})
+	}
+}
+
+func TestToGRPCUnknownBase(t *testing.T) {
+	err := Wrap("loading user", errors.New("disk offline"))
+
+	converted := ToGRPC(err)
+	if status.Code(converted) != codes.Unknown {
+		t.Errorf("ToGRPC() code = %v, want %v", status.Code(converted), codes.Unknown)
+	}
+
+	rebuilt := FromGRPC(converted)
+	if status.Code(rebuilt) != codes.Unknown {
+		t.Errorf("FromGRPC() code = %v, want %v", status.Code(rebuilt), codes.Unknown)
+	}
+}
+
+func TestFromGRPCNonStatus(t *testing.T) {
+	err := errors.New("not a status error")
+	if got := FromGRPC(err); got != err {
+		t.Errorf("FromGRPC(%v) = %v, want unchanged error", err, got)
+	}
+}
@@ -0,0 +1,42 @@
+package svcerr
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+//headersFor builds the HTTP headers implied by err's RetryInfo and
+//ResourceInfo details: a RetryInfo's RetryDelay becomes Retry-After
+//(in whole seconds), and a ResourceInfo's ResourceType becomes
+//X-Resource-Type.
+func headersFor(details []interface{}) http.Header {
+	header := http.Header{}
+	for _, d := range details {
+		switch detail := d.(type) {
+		case *RetryInfo:
+			if detail.RetryDelay != nil {
+				seconds := int64(detail.RetryDelay.AsDuration().Round(1e9).Seconds())
+				header.Set("Retry-After", strconv.FormatInt(seconds, 10))
+			}
+		case *ResourceInfo:
+			if detail.ResourceType != "" {
+				header.Set("X-Resource-Type", detail.ResourceType)
+			}
+		}
+	}
+	return header
+}
+
+//WriteHTTP - converts err via ConvHTTP and writes it to w in one call:
+//the RetryInfo/ResourceInfo headers, the HTTP status code, and the
+//JSON-encoded SvcErr body.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	se, header := ConvHTTP(err)
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(se.Rest.Code)
+	json.NewEncoder(w).Encode(se)
+}
@@ -0,0 +1,63 @@
+package svcerr
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestConvHTTPHeaders(t *testing.T) {
+	err := ResourceExhausted("quota exceeded",
+		&RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+		&ResourceInfo{ResourceType: "api-quota"},
+		&QuotaFailure{Violations: []*QuotaViolation{
+			{Subject: "user:42", Description: "daily quota exceeded"},
+		}},
+	)
+
+	se, header := ConvHTTP(err)
+
+	if got, want := header.Get("Retry-After"), "30"; got != want {
+		t.Errorf("Retry-After = %q, want %q", got, want)
+	}
+	if got, want := header.Get("X-Resource-Type"), "api-quota"; got != want {
+		t.Errorf("X-Resource-Type = %q, want %q", got, want)
+	}
+	if len(se.Rest.QuotaViolations) != 1 || se.Rest.QuotaViolations[0].Subject != "user:42" {
+		t.Errorf("QuotaViolations = %+v, want one violation for user:42", se.Rest.QuotaViolations)
+	}
+}
+
+func TestConvHTTPNoHeadersWithoutDetails(t *testing.T) {
+	_, header := ConvHTTP(NotFound("missing"))
+
+	if got := header.Get("Retry-After"); got != "" {
+		t.Errorf("Retry-After = %q, want empty", got)
+	}
+	if got := header.Get("X-Resource-Type"); got != "" {
+		t.Errorf("X-Resource-Type = %q, want empty", got)
+	}
+}
+
+func TestWriteHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	WriteHTTP(rec, ResourceExhausted("quota exceeded",
+		&RetryInfo{RetryDelay: durationpb.New(5 * time.Second)},
+	))
+
+	if got, want := rec.Code, 429; got != want {
+		t.Errorf("status = %d, want %d", got, want)
+	}
+	if got, want := rec.Header().Get("Retry-After"), "5"; got != want {
+		t.Errorf("Retry-After = %q, want %q", got, want)
+	}
+	if got, want := rec.Header().Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+	if rec.Body.Len() == 0 {
+		t.Error("body is empty, want JSON-encoded SvcErr")
+	}
+}
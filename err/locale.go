@@ -0,0 +1,151 @@
+package svcerr
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//MessageKey identifies a family of localized templates in the message
+//catalog. The code-named constructors (InvalidArgs, NotFound, ...) use
+//their gRPC code's name as the key automatically; WithMessageKey lets a
+//caller pick a more specific key when several distinct errors share one
+//gRPC code but need different translations.
+//
+//Note: this picks a key after construction rather than adding it as a
+//constructor parameter, so InvalidArgs/NotFound/... stay unchanged and
+//non-breaking for every existing caller; a caller that wants a
+//non-default key from the start pairs the constructor with
+//WithMessageKey in the same expression instead of passing it inline.
+type MessageKey string
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = map[MessageKey]map[string]string{}
+)
+
+//RegisterMessage - registers the localized template used for code in
+//locale (a BCP 47 language tag, e.g. "en", "fr-CA"). Call during
+//init/startup for every locale a code should be translatable to; errors
+//built afterwards via the code-named constructors pick these up
+//automatically, and ConvHTTPLocalized selects among them by
+//Accept-Language.
+func RegisterMessage(code codes.Code, locale, template string) {
+	registerTemplate(MessageKey(code.String()), locale, template)
+}
+
+func registerTemplate(key MessageKey, locale, template string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	m, ok := catalog[key]
+	if !ok {
+		m = map[string]string{}
+		catalog[key] = m
+	}
+	m[locale] = template
+}
+
+func lookupCatalog(key MessageKey) (map[string]string, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	m, ok := catalog[key]
+	return m, ok
+}
+
+//localizedDetails builds one errdetails.LocalizedMessage per locale
+//registered under key, or nil if key has no templates registered.
+func localizedDetails(key MessageKey) []proto.Message {
+	templates, ok := lookupCatalog(key)
+	if !ok {
+		return nil
+	}
+	details := make([]proto.Message, 0, len(templates))
+	for locale, template := range templates {
+		details = append(details, &LocalizedMessage{Locale: locale, Message: template})
+	}
+	return details
+}
+
+//WithMessageKey - attaches every locale registered under key (see
+//RegisterMessage) to err as errdetails.LocalizedMessage details, so
+//ConvHTTPLocalized can later pick the best match for a request's
+//Accept-Language. err is returned unchanged if it doesn't carry a gRPC
+//status, or if key has no templates registered.
+func WithMessageKey(err error, key MessageKey) error {
+	details := localizedDetails(key)
+	if details == nil {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	withDetails, derr := st.WithDetails(details...)
+	if derr != nil {
+		return err
+	}
+	return &codeError{err: withDetails.Err(), code: st.Code()}
+}
+
+//ConvHTTPLocalized - like ConvHTTP, but overrides RestErr.Message with the
+//errdetails.LocalizedMessage detail whose locale best matches
+//acceptLanguage (a request's Accept-Language header value), falling back
+//to the base Message if err carries no LocalizedMessage details or none
+//of them parse as a valid language tag.
+func ConvHTTPLocalized(err error, acceptLanguage string) (SvcErr, http.Header) {
+	se, header := ConvHTTP(err)
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return se, header
+	}
+
+	// byLocale keeps the last LocalizedMessage seen for a given locale, so
+	// a WithMessageKey override (attached after the code-named constructor's
+	// own default-key message) wins over the default for that locale.
+	byLocale := map[string]string{}
+	var order []string
+	for _, d := range st.Details() {
+		lm, ok := d.(*LocalizedMessage)
+		if !ok {
+			continue
+		}
+		if _, seen := byLocale[lm.Locale]; !seen {
+			order = append(order, lm.Locale)
+		}
+		byLocale[lm.Locale] = lm.Message
+	}
+
+	var (
+		tags     []language.Tag
+		messages []string
+	)
+	for _, locale := range order {
+		tag, terr := language.Parse(locale)
+		if terr != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		messages = append(messages, byLocale[locale])
+	}
+	if len(tags) == 0 {
+		return se, header
+	}
+
+	matcher := language.NewMatcher(tags)
+	_, idx, _ := matcher.Match(acceptLanguageTags(acceptLanguage)...)
+	se.Rest.Message = messages[idx]
+	return se, header
+}
+
+func acceptLanguageTags(acceptLanguage string) []language.Tag {
+	tags, _, perr := language.ParseAcceptLanguage(acceptLanguage)
+	if perr != nil || len(tags) == 0 {
+		return []language.Tag{language.Und}
+	}
+	return tags
+}
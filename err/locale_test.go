@@ -0,0 +1,77 @@
+package svcerr
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+//registerTestMessages registers templates for code and removes them again
+//once the calling test finishes, so the shared catalog doesn't leak into
+//other tests.
+func registerTestMessages(t *testing.T, code codes.Code, templates map[string]string) {
+	t.Helper()
+	for locale, template := range templates {
+		RegisterMessage(code, locale, template)
+	}
+	t.Cleanup(func() {
+		catalogMu.Lock()
+		delete(catalog, MessageKey(code.String()))
+		catalogMu.Unlock()
+	})
+}
+
+func TestConvHTTPLocalizedPicksBestMatch(t *testing.T) {
+	registerTestMessages(t, codes.Unauthenticated, map[string]string{
+		"en": "Please sign in",
+		"fr": "Veuillez vous connecter",
+	})
+
+	err := Unauthenticated("must authenticate")
+
+	se, _ := ConvHTTPLocalized(err, "fr-CA,fr;q=0.9,en;q=0.5")
+	if got, want := se.Rest.Message, "Veuillez vous connecter"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
+func TestConvHTTPLocalizedFallsBackWithoutMatch(t *testing.T) {
+	registerTestMessages(t, codes.PermissionDenied, map[string]string{
+		"en": "You are not allowed to do that",
+	})
+
+	err := PermDenied("forbidden")
+
+	se, _ := ConvHTTPLocalized(err, "de")
+	if got, want := se.Rest.Message, "You are not allowed to do that"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
+func TestConvHTTPLocalizedNoCatalogEntry(t *testing.T) {
+	err := Aborted("conflict")
+
+	se, _ := ConvHTTPLocalized(err, "fr")
+	if got, want := se.Rest.Message, "conflict"; got != want {
+		t.Errorf("Message = %q, want %q (base message)", got, want)
+	}
+}
+
+func TestWithMessageKeyOverridesDefaultKey(t *testing.T) {
+	registerTestMessages(t, codes.FailedPrecondition, map[string]string{
+		"en": "generic precondition failure",
+	})
+	t.Cleanup(func() {
+		catalogMu.Lock()
+		delete(catalog, MessageKey("account-locked"))
+		catalogMu.Unlock()
+	})
+	registerTemplate("account-locked", "en", "your account is locked")
+
+	err := WithMessageKey(FailedPreCondition("account locked"), "account-locked")
+
+	se, _ := ConvHTTPLocalized(err, "en")
+	if got, want := se.Rest.Message, "your account is locked"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package svcerr
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Sentinel errors, one per gRPC code, so callers can classify errors with
+//errors.Is without importing codes/status themselves. Every error created
+//by the constructors in this package (NotFound, InvalidArgs, ...) matches
+//the sentinel for its own code, including through Wrap and after
+//FromGRPC. A status error built outside this package (status.Error, or
+//one a gRPC client returns as is) does not match a sentinel.
+var (
+	ErrCanceled           error = codeSentinel(codes.Canceled)
+	ErrUnknown            error = codeSentinel(codes.Unknown)
+	ErrInvalidArgument    error = codeSentinel(codes.InvalidArgument)
+	ErrDeadlineExceeded   error = codeSentinel(codes.DeadlineExceeded)
+	ErrNotFound           error = codeSentinel(codes.NotFound)
+	ErrAlreadyExists      error = codeSentinel(codes.AlreadyExists)
+	ErrPermissionDenied   error = codeSentinel(codes.PermissionDenied)
+	ErrResourceExhausted  error = codeSentinel(codes.ResourceExhausted)
+	ErrFailedPrecondition error = codeSentinel(codes.FailedPrecondition)
+	ErrAborted            error = codeSentinel(codes.Aborted)
+	ErrOutOfRange         error = codeSentinel(codes.OutOfRange)
+	ErrUnimplemented      error = codeSentinel(codes.Unimplemented)
+	ErrInternal           error = codeSentinel(codes.Internal)
+	ErrUnavailable        error = codeSentinel(codes.Unavailable)
+	ErrDataLoss           error = codeSentinel(codes.DataLoss)
+	ErrUnauthenticated    error = codeSentinel(codes.Unauthenticated)
+)
+
+//codeSentinel - minimal status-carrying error used only as an errors.Is
+//target; it exists so the sentinels above can be compared against by code
+//without each one allocating a full status error.
+type codeSentinel codes.Code
+
+func (s codeSentinel) Error() string { return codes.Code(s).String() }
+
+func (s codeSentinel) GRPCStatus() *status.Status {
+	return status.New(codes.Code(s), codes.Code(s).String())
+}
+
+//codeError wraps the status error returned by newErr so that errors.Is
+//can classify it against a sentinel (or any other status error) by code,
+//while errors.Unwrap/As still reach the underlying status error.
+type codeError struct {
+	err  error
+	code codes.Code
+}
+
+func (e *codeError) Error() string { return e.err.Error() }
+
+func (e *codeError) Unwrap() error { return e.err }
+
+func (e *codeError) GRPCStatus() *status.Status {
+	if s, ok := status.FromError(e.err); ok {
+		return s
+	}
+	return status.New(e.code, e.err.Error())
+}
+
+//Is - reports a match when target is the sentinel (or any status error)
+//for the same gRPC code as e.
+func (e *codeError) Is(target error) bool {
+	s, ok := status.FromError(target)
+	return ok && s.Code() == e.code
+}
+
+//classify turns context.Canceled/context.DeadlineExceeded into a
+//codeError carrying the matching gRPC code, so errors.Is(Wrap(text, ctx.Err()), ErrCanceled)
+//succeeds without the caller having to convert ctx errors by hand. Any
+//other error, including one already produced by this package, passes
+//through unchanged.
+func classify(err error) error {
+	var gs grpcStatuser
+	if errors.As(err, &gs) {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &codeError{err: err, code: codes.Canceled}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &codeError{err: err, code: codes.DeadlineExceeded}
+	default:
+		return err
+	}
+}
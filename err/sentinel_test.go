@@ -0,0 +1,92 @@
+package svcerr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestErrorsIsSentinel(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "NotFound matches ErrNotFound",
+			err:    NotFound("missing record"),
+			target: ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "NotFound does not match ErrInternal",
+			err:    NotFound("missing record"),
+			target: ErrInternal,
+			want:   false,
+		},
+		{
+			name:   "Wrap preserves sentinel match through the chain",
+			err:    Wrap("loading user", NotFound("missing record")),
+			target: ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "Wrap classifies context.Canceled as ErrCanceled",
+			err:    Wrap("rpc call", context.Canceled),
+			target: ErrCanceled,
+			want:   true,
+		},
+		{
+			name:   "Wrap classifies context.DeadlineExceeded as ErrDeadlineExceeded",
+			err:    Wrap("rpc call", context.DeadlineExceeded),
+			target: ErrDeadlineExceeded,
+			want:   true,
+		},
+		{
+			name:   "Wrapped context.Canceled still matches the stdlib sentinel",
+			err:    Wrap("rpc call", context.Canceled),
+			target: context.Canceled,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//TestClassifiedCanceledBeyondErrorsIs exercises every consumer of
+//codeError.GRPCStatus, not just errors.Is, on a Wrap(text,
+//context.Canceled) chain: classify's codeError wraps context.Canceled
+//directly (not a status error), so GRPCStatus must synthesize one from
+//e.code rather than returning nil.
+func TestClassifiedCanceledBeyondErrorsIs(t *testing.T) {
+	err := Wrap("rpc call", context.Canceled)
+
+	if got, want := Code(err), codes.Canceled; got != want {
+		t.Errorf("Code() = %v, want %v", got, want)
+	}
+
+	if !IsValid(err) {
+		t.Error("IsValid() = false, want true")
+	}
+
+	se, _ := ConvHTTP(err)
+	if got, want := se.Rest.Code, 499; got != want {
+		t.Errorf("ConvHTTP().Rest.Code = %d, want %d", got, want)
+	}
+	if got, want := se.Rest.Desc, codes.Canceled.String(); got != want {
+		t.Errorf("ConvHTTP().Rest.Desc = %q, want %q", got, want)
+	}
+
+	gerr := ToGRPC(err)
+	if got, want := Code(gerr), codes.Canceled; got != want {
+		t.Errorf("Code(ToGRPC()) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package svcerr
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync/atomic"
+
+	"google.golang.org/grpc/status"
+)
+
+//stackMarker identifies the DebugInfo detail added to carry a captured call
+//stack, distinguishing it from a Wrap chain layer (chainPrefix) or a detail
+//the caller attached on purpose.
+const stackMarker = "svcerr.stack"
+
+//stackCapture is 1 when newErr should automatically attach a call stack to
+//every error it creates. Off by default; enable for local development via
+//EnableStack or the SVCERR_STACK=1 environment variable.
+var stackCapture int32
+
+func init() {
+	if os.Getenv("SVCERR_STACK") == "1" {
+		atomic.StoreInt32(&stackCapture, 1)
+	}
+}
+
+//EnableStack turns automatic call-stack capture on or off for every error
+//created afterwards. Capturing a stack on every error is relatively
+//expensive, so this defaults to off and is meant for local development
+//(see also the SVCERR_STACK=1 environment variable).
+func EnableStack(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&stackCapture, 1)
+	} else {
+		atomic.StoreInt32(&stackCapture, 0)
+	}
+}
+
+func stackEnabled() bool {
+	return atomic.LoadInt32(&stackCapture) == 1
+}
+
+//captureFrames records the call stack above its caller, skipping skip
+//additional frames on top of that. Each entry is formatted as
+//"function (file:line)".
+func captureFrames(skip int) []string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	entries := make([]string, 0, n)
+	for {
+		frame, more := frames.Next()
+		entries = append(entries, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return entries
+}
+
+//WithStack - attaches the current call stack to err as an errdetails.DebugInfo
+//detail, regardless of EnableStack/SVCERR_STACK. err is returned unchanged
+//if it doesn't carry a gRPC status. Use this to capture a stack for a
+//specific error without turning on capture for every error.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	withDetails, derr := st.WithDetails(&DebugInfo{Detail: stackMarker, StackEntries: captureFrames(1)})
+	if derr != nil {
+		return err
+	}
+	return &codeError{err: withDetails.Err(), code: st.Code()}
+}
+
+//Stack - returns the call-stack frames attached to err via WithStack or
+//automatic capture (EnableStack/SVCERR_STACK), or nil if none were
+//attached.
+func Stack(err error) []string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		if di, ok := d.(*DebugInfo); ok && di.Detail == stackMarker {
+			return di.StackEntries
+		}
+	}
+	return nil
+}
+
+//visibleDetails filters out the stack detail from details unless stack
+//capture is enabled, so String and ConvHTTP don't leak raw call stacks
+//into their output by default.
+func visibleDetails(details []interface{}) []interface{} {
+	if stackEnabled() {
+		return details
+	}
+	visible := make([]interface{}, 0, len(details))
+	for _, d := range details {
+		if di, ok := d.(*DebugInfo); ok && di.Detail == stackMarker {
+			continue
+		}
+		visible = append(visible, d)
+	}
+	return visible
+}
@@ -0,0 +1,60 @@
+package svcerr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithStack(t *testing.T) {
+	err := WithStack(InternalErr("something broke"))
+
+	frames := Stack(err)
+	if len(frames) == 0 {
+		t.Fatal("Stack() = empty, want at least one frame")
+	}
+	if !strings.Contains(frames[0], "TestWithStack") {
+		t.Errorf("Stack()[0] = %q, want it to mention the calling test", frames[0])
+	}
+}
+
+func TestStackNoneAttached(t *testing.T) {
+	err := InternalErr("something broke")
+
+	if frames := Stack(err); frames != nil {
+		t.Errorf("Stack() = %v, want nil", frames)
+	}
+}
+
+func TestEnableStackAutoCapture(t *testing.T) {
+	EnableStack(true)
+	defer EnableStack(false)
+
+	err := InternalErr("something broke")
+
+	frames := Stack(err)
+	if len(frames) == 0 {
+		t.Fatal("Stack() = empty, want at least one frame")
+	}
+	if !strings.Contains(frames[0], "TestEnableStackAutoCapture") {
+		t.Errorf("Stack()[0] = %q, want it to mention the calling test", frames[0])
+	}
+}
+
+func TestStackHiddenFromStringByDefault(t *testing.T) {
+	err := WithStack(InternalErr("something broke"))
+
+	if got := String(err); strings.Contains(got, stackMarker) {
+		t.Errorf("String() = %q, should not include the stack by default", got)
+	}
+}
+
+func TestStackShownInStringWhenEnabled(t *testing.T) {
+	EnableStack(true)
+	defer EnableStack(false)
+
+	err := WithStack(InternalErr("something broke"))
+
+	if got := String(err); !strings.Contains(got, stackMarker) {
+		t.Errorf("String() = %q, should include the stack once enabled", got)
+	}
+}